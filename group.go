@@ -0,0 +1,163 @@
+package safego
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// token is an empty struct used as a semaphore unit in Group's concurrency limiter.
+type token struct{}
+
+// Group manages a collection of goroutines working on subtasks that are part of
+// the same overall task, mirroring the API of golang.org/x/sync/errgroup but with
+// panic recovery baked in: a panic in any child goroutine is recovered, wrapped in
+// a *PanicError (consistent with ChanGoWithError), and treated like any other
+// returned error.
+//
+// A zero Group is valid, has no limit on the number of active goroutines, and
+// does not cancel on error. Use WithContext to derive a Group whose context is
+// cancelled as soon as the first error (or panic) occurs, so sibling goroutines
+// can observe the cancellation.
+//
+// Example:
+//
+//	g, ctx := safego.WithContext(context.Background())
+//	for _, url := range urls {
+//	    url := url
+//	    g.Go(func() error {
+//	        return fetch(ctx, url)
+//	    })
+//	}
+//	if err := g.Wait(); err != nil {
+//	    log.Printf("fetch failed: %v", err)
+//	}
+type Group struct {
+	cancel context.CancelFunc
+
+	wg sync.WaitGroup
+
+	sem chan token
+
+	errOnce sync.Once
+	err     error
+}
+
+// WithContext returns a new Group and an associated Context derived from ctx.
+//
+// The derived Context is cancelled the first time a function passed to Go
+// returns a non-nil error, panics, or the first time Wait returns, whichever
+// occurs first.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// done releases a slot in the concurrency limiter, if any, and marks one
+// goroutine as finished.
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// Go calls the given function in a new goroutine.
+// It blocks until the new goroutine can be added without the number of
+// active goroutines in the group exceeding the configured limit.
+//
+// The first call to return a non-nil error, or to panic, cancels the group's
+// context, if any. The error (or the panic, wrapped in a *PanicError) will be
+// returned by Wait.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- token{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		g.run(fn)
+	}()
+}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines in the group is currently below the configured limit.
+//
+// It returns true if the goroutine was started, false otherwise.
+func (g *Group) TryGo(fn func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- token{}:
+		default:
+			return false
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		g.run(fn)
+	}()
+	return true
+}
+
+// run executes fn with panic recovery, recording the first error or panic
+// and cancelling the group's context, if any.
+func (g *Group) run(fn func() error) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.recordErr(&PanicError{
+				Value:      r,
+				StackTrace: string(debug.Stack()),
+			})
+		}
+	}()
+
+	if err := fn(); err != nil {
+		g.recordErr(err)
+	}
+}
+
+// recordErr stores the first error reported to the group and cancels its
+// context, if any. Subsequent calls are no-ops.
+func (g *Group) recordErr(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		if g.cancel != nil {
+			g.cancel()
+		}
+	})
+}
+
+// SetLimit limits the number of active goroutines in this group to n.
+// A negative value indicates no limit.
+//
+// Any subsequent call to Go after SetLimit will block until it can add an
+// active goroutine without exceeding the configured limit; TryGo instead
+// returns false while the limit is reached.
+//
+// SetLimit must not be called concurrently with Go or TryGo, or while
+// goroutines are still active. For any n >= 0 it panics if goroutines
+// started under a previously set limit are still in flight.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic("safego: SetLimit called while goroutines are still active")
+	}
+	g.sem = make(chan token, n)
+}
+
+// Wait blocks until all function calls from the Go method have returned, then
+// returns the first non-nil error (if any) from them, cancelling the group's
+// context, if any.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.err
+}
@@ -1,6 +1,14 @@
 package safego
 
-import "log"
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
 
 // Logger is an interface for logging messages from safego.
 // It can be implemented to provide custom logging behavior.
@@ -20,6 +28,14 @@ type Logger interface {
 	Printf(format string, v ...interface{})
 }
 
+// panicLogger is implemented by loggers that can emit a structured panic
+// event instead of a single formatted message. Only *Handler implements it
+// today; SetLogger still accepts any Logger, and reportPanic falls back to
+// Printf for the rest.
+type panicLogger interface {
+	LogPanic(pe *PanicError, caller string, contextErr error)
+}
+
 type defaultLogger struct{}
 
 func (l *defaultLogger) Printf(format string, v ...interface{}) {
@@ -36,6 +52,10 @@ var logger Logger = &defaultLogger{}
 // If nil is passed, logging will be disabled (noop logger).
 // By default, safego uses Go's standard logger.
 //
+// Passing a *Handler (see NewHandler) additionally switches panic
+// reporting to structured slog records instead of a single formatted
+// message.
+//
 // Example:
 //
 //	type myLogger struct{}
@@ -48,6 +68,106 @@ var logger Logger = &defaultLogger{}
 func SetLogger(l Logger) {
 	if l != nil {
 		logger = l
+	} else {
+		logger = &noopLogger{}
+	}
+}
+
+// Handler adapts a log/slog.Handler for use as a safego Logger. Unlike the
+// plain Logger interface, it reports recovered panics as structured slog
+// records with an "event=panic" attribute, the panic value, stack trace,
+// launch-site caller, and (when available) the underlying context error,
+// instead of a single formatted string.
+//
+// Example:
+//
+//	safego.SetLogger(safego.NewHandler(slog.NewJSONHandler(os.Stdout, nil)))
+type Handler struct {
+	h slog.Handler
+}
+
+// NewHandler wraps h so it can be installed with SetLogger.
+func NewHandler(h slog.Handler) *Handler {
+	return &Handler{h: h}
+}
+
+// Printf implements Logger for messages that aren't panic events (e.g. the
+// "goroutine cancelled" notices), using the string formatted from format
+// and v as the slog record's message.
+func (h *Handler) Printf(format string, v ...interface{}) {
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, fmt.Sprintf(format, v...), 0)
+	_ = h.h.Handle(context.Background(), r)
+}
+
+// LogPanic implements panicLogger, emitting a structured record for a
+// recovered panic. caller is the "file:line" launch site captured when the
+// goroutine was spawned; contextErr is the supplied context's error, if
+// any, at the time of the panic.
+func (h *Handler) LogPanic(pe *PanicError, caller string, contextErr error) {
+	r := slog.NewRecord(time.Now(), slog.LevelError, "recovered from panic in goroutine", 0)
+	r.AddAttrs(
+		slog.String("event", "panic"),
+		slog.Any("panic_value", pe.Value),
+		slog.String("stack", pe.StackTrace),
+		slog.String("caller", caller),
+	)
+	if contextErr != nil {
+		r.AddAttrs(slog.String("context_err", contextErr.Error()))
+	}
+	_ = h.h.Handle(context.Background(), r)
+}
+
+var (
+	panicHooksMu sync.Mutex
+	panicHooks   []func(*PanicError)
+)
+
+// OnPanic registers a process-wide hook invoked for every panic recovered
+// by Go, GoWithErrorHandler, ChanGo, or ChanGoWithError, regardless of
+// whether the call site also logs the panic or handles it through
+// GoWithErrorHandler's errorHandler or a ChanGo* result. It's meant for
+// integrations (Sentry, OpenTelemetry, ...) that need to observe every
+// recovered panic in one place without switching every call site to
+// GoWithErrorHandler.
+//
+// Hooks are called synchronously, in registration order, from the
+// goroutine that recovered the panic; they should not block or panic.
+func OnPanic(fn func(*PanicError)) {
+	panicHooksMu.Lock()
+	defer panicHooksMu.Unlock()
+	panicHooks = append(panicHooks, fn)
+}
+
+// firePanicHooks invokes every hook registered via OnPanic with pe.
+func firePanicHooks(pe *PanicError) {
+	panicHooksMu.Lock()
+	hooks := make([]func(*PanicError), len(panicHooks))
+	copy(hooks, panicHooks)
+	panicHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook(pe)
+	}
+}
+
+// reportPanic notifies the configured logger of a recovered panic, using
+// the structured panicLogger path when available, and then fires every
+// hook registered via OnPanic.
+func reportPanic(pe *PanicError, caller string, contextErr error) {
+	if pl, ok := logger.(panicLogger); ok {
+		pl.LogPanic(pe, caller, contextErr)
+	} else {
+		logger.Printf("recovered from panic in goroutine: %v", pe.Value)
+	}
+	firePanicHooks(pe)
+}
+
+// callSite formats the file:line reported by runtime.Caller(skip) as a
+// single string, or "" if it couldn't be determined.
+func callSite(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
 	}
-	logger = &noopLogger{}
+	return fmt.Sprintf("%s:%d", file, line)
 }
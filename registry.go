@@ -0,0 +1,277 @@
+package safego
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerState describes the lifecycle state of a goroutine tracked by the
+// worker registry.
+type WorkerState int32
+
+const (
+	// WorkerRunning means the goroutine has not returned, panicked, or been
+	// cancelled yet.
+	WorkerRunning WorkerState = iota
+	// WorkerDone means the goroutine returned normally.
+	WorkerDone
+	// WorkerPanicked means the goroutine's panic was recovered by safego.
+	WorkerPanicked
+	// WorkerCancelled means the goroutine's context was cancelled before fn
+	// finished running.
+	WorkerCancelled
+)
+
+// String returns a lower-case, human-readable name for the state.
+func (s WorkerState) String() string {
+	switch s {
+	case WorkerRunning:
+		return "running"
+	case WorkerDone:
+		return "done"
+	case WorkerPanicked:
+		return "panicked"
+	case WorkerCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// WorkerInfo describes a single goroutine launched through Go, GoNamed,
+// GoWithErrorHandler, ChanGo, or ChanGoWithError while the registry was
+// enabled; see EnableRegistry.
+type WorkerInfo struct {
+	ID        int64  // monotonically increasing, unique for the process lifetime
+	Name      string // caller-supplied tag, empty unless a *Named variant was used
+	File      string // launch site, captured via runtime.Caller at spawn time
+	Line      int
+	StartTime time.Time
+
+	state       int32
+	goroutineID int64 // 0 until captured by the worker's own goroutine
+}
+
+// State returns the worker's current lifecycle state.
+func (w *WorkerInfo) State() WorkerState {
+	return WorkerState(atomic.LoadInt32(&w.state))
+}
+
+// Stack returns a best-effort stack trace for the worker's goroutine,
+// captured lazily by scanning all running goroutines for a matching ID. It
+// returns nil if the goroutine ID was never captured, or can no longer be
+// found (typically because the worker has already finished).
+func (w *WorkerInfo) Stack() []byte {
+	id := atomic.LoadInt64(&w.goroutineID)
+	if id == 0 {
+		return nil
+	}
+	return goroutineStack(id)
+}
+
+var (
+	registryEnabled int32
+
+	registryMu       sync.Mutex
+	registryNext     int64
+	registryByID     = make(map[int64]*WorkerInfo)
+	registryFinished []int64 // IDs of finished workers, oldest first
+
+	registryRetention int32 = 1000
+)
+
+// EnableRegistry turns the worker registry on or off. It is disabled by
+// default: tracking costs a runtime.Caller call plus a mutex-guarded map
+// update per spawn and per completion, so services that never call
+// Workers, HangingWorkers, or DumpWorkers shouldn't pay for it.
+func EnableRegistry(enable bool) {
+	if enable {
+		atomic.StoreInt32(&registryEnabled, 1)
+	} else {
+		atomic.StoreInt32(&registryEnabled, 0)
+	}
+}
+
+func registryIsEnabled() bool {
+	return atomic.LoadInt32(&registryEnabled) != 0
+}
+
+// SetRegistryRetention caps how many finished workers (done, panicked, or
+// cancelled) the registry keeps before evicting the oldest ones; running
+// workers are never evicted regardless of this setting. This bounds memory
+// use in long-running services that spawn many short-lived goroutines with
+// the registry enabled. It defaults to 1000. n <= 0 disables eviction and
+// keeps every finished worker for the lifetime of the process.
+func SetRegistryRetention(n int) {
+	atomic.StoreInt32(&registryRetention, int32(n))
+}
+
+// registerWorker records a newly spawned goroutine in the registry and
+// returns its WorkerInfo, or nil if the registry is disabled. It must be
+// called synchronously from the unexported doGo/doGoWithErrorHandler/
+// doChanGo/doChanGoWithError implementations, before the "go" statement, one
+// call frame below the exported Go/GoNamed/... entry point, so that
+// runtime.Caller resolves to the user's launch site.
+func registerWorker(name string) *WorkerInfo {
+	if !registryIsEnabled() {
+		return nil
+	}
+
+	file, line := "", 0
+	if _, f, l, ok := runtime.Caller(3); ok {
+		file, line = f, l
+	}
+
+	w := &WorkerInfo{
+		ID:        atomic.AddInt64(&registryNext, 1),
+		Name:      name,
+		File:      file,
+		Line:      line,
+		StartTime: time.Now(),
+	}
+
+	registryMu.Lock()
+	registryByID[w.ID] = w
+	registryMu.Unlock()
+	return w
+}
+
+// captureGoroutineID records the ID of the calling goroutine on w, so that
+// Stack can later find it. It must be called from inside the worker's own
+// goroutine. It is a no-op if w is nil.
+func captureGoroutineID(w *WorkerInfo) {
+	if w == nil {
+		return
+	}
+	atomic.StoreInt64(&w.goroutineID, currentGoroutineID())
+}
+
+// finishWorker transitions w out of WorkerRunning into state, unless it has
+// already been transitioned (e.g. cancellation racing with completion), and
+// then makes it eligible for eviction by evictFinishedLocked. It is a no-op
+// if w is nil.
+func finishWorker(w *WorkerInfo, state WorkerState) {
+	if w == nil {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&w.state, int32(WorkerRunning), int32(state)) {
+		return
+	}
+
+	registryMu.Lock()
+	registryFinished = append(registryFinished, w.ID)
+	evictFinishedLocked()
+	registryMu.Unlock()
+}
+
+// evictFinishedLocked deletes the oldest finished workers from registryByID
+// until registryFinished holds at most registryRetention entries. Callers
+// must hold registryMu.
+func evictFinishedLocked() {
+	max := atomic.LoadInt32(&registryRetention)
+	if max <= 0 {
+		return
+	}
+	for int32(len(registryFinished)) > max {
+		delete(registryByID, registryFinished[0])
+		registryFinished = registryFinished[1:]
+	}
+}
+
+// Workers returns a snapshot of every worker currently tracked by the
+// registry, running or finished, ordered by ID.
+func Workers() []WorkerInfo {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	out := make([]WorkerInfo, 0, len(registryByID))
+	for _, w := range registryByID {
+		out = append(out, WorkerInfo{
+			ID:          w.ID,
+			Name:        w.Name,
+			File:        w.File,
+			Line:        w.Line,
+			StartTime:   w.StartTime,
+			state:       atomic.LoadInt32(&w.state),
+			goroutineID: atomic.LoadInt64(&w.goroutineID),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// HangingWorkers returns the tracked workers that are still running and
+// have been running for longer than threshold. It is meant for exposing a
+// debug endpoint in long-running services to flag goroutines that may have
+// leaked or deadlocked.
+func HangingWorkers(threshold time.Duration) []WorkerInfo {
+	now := time.Now()
+
+	var hanging []WorkerInfo
+	for _, w := range Workers() {
+		if w.State() == WorkerRunning && now.Sub(w.StartTime) > threshold {
+			hanging = append(hanging, w)
+		}
+	}
+	return hanging
+}
+
+// DumpWorkers writes a human-readable summary of every tracked worker to w,
+// one line per worker, ordered by ID.
+func DumpWorkers(w io.Writer) {
+	for _, info := range Workers() {
+		fmt.Fprintf(w, "#%d %q state=%s site=%s:%d started=%s\n",
+			info.ID, info.Name, info.State(), info.File, info.Line, info.StartTime.Format(time.RFC3339))
+	}
+}
+
+// currentGoroutineID parses the calling goroutine's own ID out of the
+// header line runtime.Stack prints for it, e.g. "goroutine 7 [running]:".
+// It returns 0 if the ID couldn't be parsed.
+func currentGoroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return 0
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(string(fields[1]), "%d", &id); err != nil {
+		return 0
+	}
+	return id
+}
+
+// goroutineStack dumps the stacks of every goroutine and returns just the
+// block belonging to id, or nil if id is no longer running.
+func goroutineStack(id int64) []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+
+	prefix := []byte(fmt.Sprintf("goroutine %d ", id))
+	start := bytes.Index(buf, prefix)
+	if start < 0 {
+		return nil
+	}
+
+	block := buf[start:]
+	if end := bytes.Index(block, []byte("\ngoroutine ")); end >= 0 {
+		return block[:end+1]
+	}
+	return block
+}
@@ -7,7 +7,14 @@
 //   - ChanGo: Returns a channel to wait for completion
 //   - ChanGoWithError: Returns a channel to wait for completion with error support
 //
-// All functions support optional context.Context for cancellation.
+// Each pattern also has a Named variant (GoNamed, ChanGoNamed, ...) that
+// tags the spawned goroutine with a caller-supplied name; tagged goroutines
+// show up under that name in Workers, HangingWorkers, and DumpWorkers once
+// EnableRegistry(true) has been called.
+//
+// All functions support optional context.Context for cancellation: fn runs in
+// an inner goroutine raced against ctx.Done(), so cancellation is observed
+// even if fn is already running, not just before it starts.
 //
 // Example usage:
 //
@@ -69,23 +76,75 @@ type Done struct {
 //	    time.Sleep(10 * time.Second) // Will be cancelled
 //	}, ctx)
 func Go(fn func(), ctx ...context.Context) {
+	doGo("", fn, ctx...)
+}
+
+// GoNamed is like Go, but tags the spawned goroutine with name in the
+// worker registry (see EnableRegistry), so it can be identified via
+// Workers, HangingWorkers, or DumpWorkers.
+func GoNamed(name string, fn func(), ctx ...context.Context) {
+	doGo(name, fn, ctx...)
+}
+
+func doGo(name string, fn func(), ctx ...context.Context) {
+	w := registerWorker(name)
+	caller := callSite(3)
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				logger.Printf("recovered from panic in goroutine: %v", r)
-			}
+		if len(ctx) == 0 {
+			captureGoroutineID(w)
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					finishWorker(w, WorkerPanicked)
+					reportPanic(pe, caller, nil)
+					return
+				}
+				finishWorker(w, WorkerDone)
+			}()
+			fn()
+			return
+		}
+
+		cctx, cancel := context.WithCancelCause(ctx[0])
+		defer cancel(nil)
+
+		done := make(chan struct{})
+		go func() {
+			captureGoroutineID(w)
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					finishWorker(w, WorkerPanicked)
+					reportPanic(pe, caller, ctx[0].Err())
+					// close(done) before cancel(pe): cancel is what wakes the
+					// outer select's cctx.Done() case, so done must already
+					// be closed by then or that case can race ahead of this
+					// goroutine's own completion and misreport a CancelError.
+					close(done)
+					cancel(pe)
+					return
+				}
+				finishWorker(w, WorkerDone)
+				close(done)
+			}()
+			fn()
 		}()
-		if len(ctx) > 0 {
-			c := ctx[0]
+
+		select {
+		case <-done:
+		case <-cctx.Done():
 			select {
-			case <-c.Done():
-				logger.Printf("goroutine cancelled: %v", c.Err())
-				return
+			case <-done:
 			default:
-				fn()
+				finishWorker(w, WorkerCancelled)
+				logger.Printf("goroutine cancelled: %v", context.Cause(cctx))
 			}
-		} else {
-			fn()
 		}
 	}()
 }
@@ -110,29 +169,80 @@ func Go(fn func(), ctx ...context.Context) {
 //	    },
 //	)
 func GoWithErrorHandler(fn func() error, errorHandler func(error), ctx ...context.Context) {
+	doGoWithErrorHandler("", fn, errorHandler, ctx...)
+}
+
+// GoWithErrorHandlerNamed is like GoWithErrorHandler, but tags the spawned
+// goroutine with name in the worker registry (see EnableRegistry).
+func GoWithErrorHandlerNamed(name string, fn func() error, errorHandler func(error), ctx ...context.Context) {
+	doGoWithErrorHandler(name, fn, errorHandler, ctx...)
+}
+
+func doGoWithErrorHandler(name string, fn func() error, errorHandler func(error), ctx ...context.Context) {
+	w := registerWorker(name)
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				e := errors.New(fmt.Sprintf("recovered from panic in goroutine: %v", r))
-				errorHandler(e)
-			}
-		}()
-		if len(ctx) > 0 {
-			c := ctx[0]
-			select {
-			case <-c.Done():
-				e := errors.New(fmt.Sprintf("goroutine cancelled: %v", c.Err()))
-				errorHandler(e)
-				return
-			default:
-				if err := fn(); err != nil {
-					errorHandler(err)
+		if len(ctx) == 0 {
+			captureGoroutineID(w)
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					finishWorker(w, WorkerPanicked)
+					firePanicHooks(pe)
+					errorHandler(errors.New(fmt.Sprintf("recovered from panic in goroutine: %v", pe.Value)))
+					return
 				}
+				finishWorker(w, WorkerDone)
+			}()
+			if err := fn(); err != nil {
+				errorHandler(err)
 			}
-		} else {
+			return
+		}
+
+		cctx, cancel := context.WithCancelCause(ctx[0])
+		defer cancel(nil)
+
+		done := make(chan struct{})
+		go func() {
+			captureGoroutineID(w)
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					finishWorker(w, WorkerPanicked)
+					firePanicHooks(pe)
+					// close(done) before cancel(pe), and errorHandler is
+					// called only here: if cancel(pe) ran first, the outer
+					// select could race ahead, see cctx.Done() before done,
+					// and call errorHandler a second time with a CancelError
+					// for what is really this panic.
+					close(done)
+					cancel(pe)
+					errorHandler(errors.New(fmt.Sprintf("recovered from panic in goroutine: %v", pe.Value)))
+					return
+				}
+				finishWorker(w, WorkerDone)
+				close(done)
+			}()
 			if err := fn(); err != nil {
 				errorHandler(err)
 			}
+		}()
+
+		select {
+		case <-done:
+		case <-cctx.Done():
+			select {
+			case <-done:
+			default:
+				finishWorker(w, WorkerCancelled)
+				errorHandler(&CancelError{Cause: context.Cause(cctx)})
+			}
 		}
 	}()
 }
@@ -163,31 +273,82 @@ func GoWithErrorHandler(fn func() error, errorHandler func(error), ctx ...contex
 //	    }
 //	}
 func ChanGo(fn func(), ctx ...context.Context) chan Done {
-	var err error
+	return doChanGo("", fn, ctx...)
+}
+
+// ChanGoNamed is like ChanGo, but tags the spawned goroutine with name in
+// the worker registry (see EnableRegistry).
+func ChanGoNamed(name string, fn func(), ctx ...context.Context) chan Done {
+	return doChanGo(name, fn, ctx...)
+}
+
+func doChanGo(name string, fn func(), ctx ...context.Context) chan Done {
+	w := registerWorker(name)
 	doneCh := make(chan Done, 1)
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				err = &PanicError{
-					Value:      r,
-					StackTrace: string(debug.Stack()),
+		if len(ctx) == 0 {
+			captureGoroutineID(w)
+			var err error
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					err = pe
+					finishWorker(w, WorkerPanicked)
+					firePanicHooks(pe)
+				} else {
+					finishWorker(w, WorkerDone)
 				}
-			}
-			doneCh <- Done{Error: err}
-			close(doneCh)
+				doneCh <- Done{Error: err}
+				close(doneCh)
+			}()
+			fn()
+			return
+		}
+
+		cctx, cancel := context.WithCancelCause(ctx[0])
+		defer cancel(nil)
+
+		result := make(chan error, 1)
+		go func() {
+			captureGoroutineID(w)
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					finishWorker(w, WorkerPanicked)
+					firePanicHooks(pe)
+					// result <- pe before cancel(pe): cancel is what wakes
+					// the outer select's cctx.Done() case, so the result
+					// must already be sent or that case can race ahead and
+					// misreport a CancelError instead of this panic.
+					result <- pe
+					cancel(pe)
+					return
+				}
+				finishWorker(w, WorkerDone)
+			}()
+			fn()
+			result <- nil
 		}()
-		if len(ctx) > 0 {
-			c := ctx[0]
+
+		var err error
+		select {
+		case err = <-result:
+		case <-cctx.Done():
 			select {
-			case <-c.Done():
-				err = &CancelError{Cause: c.Err()}
-				return
+			case err = <-result:
 			default:
-				fn()
+				finishWorker(w, WorkerCancelled)
+				err = &CancelError{Cause: context.Cause(cctx)}
 			}
-		} else {
-			fn()
 		}
+		doneCh <- Done{Error: err}
+		close(doneCh)
 	}()
 	return doneCh
 }
@@ -215,35 +376,82 @@ func ChanGo(fn func(), ctx ...context.Context) chan Done {
 //	    log.Printf("Task failed: %v", result.Error)
 //	}
 func ChanGoWithError(fn func() error, ctx ...context.Context) chan Done {
-	var err error
+	return doChanGoWithError("", fn, ctx...)
+}
+
+// ChanGoWithErrorNamed is like ChanGoWithError, but tags the spawned
+// goroutine with name in the worker registry (see EnableRegistry).
+func ChanGoWithErrorNamed(name string, fn func() error, ctx ...context.Context) chan Done {
+	return doChanGoWithError(name, fn, ctx...)
+}
+
+func doChanGoWithError(name string, fn func() error, ctx ...context.Context) chan Done {
+	w := registerWorker(name)
 	doneCh := make(chan Done, 1)
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				err = &PanicError{
-					Value:      r,
-					StackTrace: string(debug.Stack()),
+		if len(ctx) == 0 {
+			captureGoroutineID(w)
+			var err error
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					err = pe
+					finishWorker(w, WorkerPanicked)
+					firePanicHooks(pe)
+				} else {
+					finishWorker(w, WorkerDone)
 				}
-			}
-			doneCh <- Done{Error: err}
-			close(doneCh)
+				doneCh <- Done{Error: err}
+				close(doneCh)
+			}()
+			err = fn()
+			return
+		}
+
+		cctx, cancel := context.WithCancelCause(ctx[0])
+		defer cancel(nil)
+
+		result := make(chan error, 1)
+		go func() {
+			captureGoroutineID(w)
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{
+						Value:      r,
+						StackTrace: string(debug.Stack()),
+					}
+					finishWorker(w, WorkerPanicked)
+					firePanicHooks(pe)
+					// result <- pe before cancel(pe), for the same reason as
+					// in doChanGo: otherwise the outer select can observe
+					// cctx.Done() before the result is sent and misreport a
+					// CancelError instead of this panic.
+					result <- pe
+					cancel(pe)
+					return
+				}
+			}()
+			err := fn()
+			finishWorker(w, WorkerDone)
+			result <- err
 		}()
-		if len(ctx) > 0 {
-			c := ctx[0]
+
+		var err error
+		select {
+		case err = <-result:
+		case <-cctx.Done():
 			select {
-			case <-c.Done():
-				err = &CancelError{Cause: c.Err()}
-				return
+			case err = <-result:
 			default:
-				if e := fn(); e != nil {
-					err = e
-				}
-			}
-		} else {
-			if e := fn(); e != nil {
-				err = e
+				finishWorker(w, WorkerCancelled)
+				err = &CancelError{Cause: context.Cause(cctx)}
 			}
 		}
+		doneCh <- Done{Error: err}
+		close(doneCh)
 	}()
 	return doneCh
 }
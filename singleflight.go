@@ -0,0 +1,198 @@
+package safego
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// errGoexit indicates the func passed to Do exited via runtime.Goexit.
+var errGoexit = errors.New("safego: runtime.Goexit called in Do function")
+
+// goroutinePrefix is the prefix emitted by debug.Stack before the actual
+// stack frames, e.g. "goroutine 7 [running]:\n". It is trimmed when
+// forwarding a recovered panic's stack trace to other waiters, since by the
+// time they observe it the originating goroutine may no longer exist.
+var goroutinePrefix = []byte("goroutine ")
+
+// Result holds the result of a Do or DoChan call, delivered to DoChan
+// subscribers of an SFGroup.
+type Result[V any] struct {
+	Val    V
+	Err    error
+	Shared bool
+}
+
+// call is an in-flight or completed SFGroup.Do call.
+type call[V any] struct {
+	wg sync.WaitGroup
+
+	val V
+	err error
+
+	// forgotten indicates whether Forget was called with this call's key
+	// while the call was still in flight.
+	forgotten bool
+
+	dups  int
+	chans []chan<- Result[V]
+}
+
+// SFGroup suppresses duplicate concurrent calls sharing the same key, the
+// way golang.org/x/sync/singleflight does, but with panic recovery: a
+// recovered panic is wrapped in a *PanicError (consistent with the rest of
+// safego) and delivered to every waiter.
+//
+// The zero value is ready to use.
+//
+// Example:
+//
+//	var g safego.SFGroup[string, []byte]
+//	v, err, shared := g.Do("user:42", func() ([]byte, error) {
+//	    return fetchUser(42)
+//	})
+type SFGroup[K comparable, V any] struct {
+	mu sync.Mutex
+	m  map[K]*call[V]
+}
+
+// Do executes and returns the results of fn, making sure that only one
+// execution is in flight for a given key at a time. If a duplicate call
+// comes in, that caller waits for the original to complete and receives the
+// same results. The return value shared indicates whether v was given to
+// multiple callers.
+//
+// If fn panics, Do wraps the panic in a *PanicError (with the stack trace at
+// the point of panic) and re-panics on every waiting caller with that value.
+// If fn calls runtime.Goexit, every waiting caller of Do also calls
+// runtime.Goexit.
+func (g *SFGroup[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[K]*call[V])
+	}
+	if c, ok := g.m[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return g.result(c)
+	}
+	c := new(call[V])
+	c.wg.Add(1)
+	g.m[key] = c
+	g.mu.Unlock()
+
+	g.doCall(c, key, fn)
+	return g.result(c)
+}
+
+// result extracts Do's return values from a completed call, re-panicking or
+// re-invoking runtime.Goexit if fn did so, so that every caller of Do
+// observes the same outcome as the original invocation.
+func (g *SFGroup[K, V]) result(c *call[V]) (v V, err error, shared bool) {
+	if panicErr, ok := c.err.(*PanicError); ok {
+		panic(panicErr)
+	}
+	if c.err == errGoexit {
+		runtime.Goexit()
+	}
+	return c.val, c.err, c.dups > 0
+}
+
+// DoChan is like Do but returns a channel that will receive the result when
+// it is ready.
+//
+// The returned channel is not closed.
+func (g *SFGroup[K, V]) DoChan(key K, fn func() (V, error)) <-chan Result[V] {
+	ch := make(chan Result[V], 1)
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[K]*call[V])
+	}
+	if c, ok := g.m[key]; ok {
+		c.dups++
+		c.chans = append(c.chans, ch)
+		g.mu.Unlock()
+		return ch
+	}
+	c := new(call[V])
+	c.chans = append(c.chans, ch)
+	c.wg.Add(1)
+	g.m[key] = c
+	g.mu.Unlock()
+
+	go g.doCall(c, key, fn)
+	return ch
+}
+
+// doCall runs fn for a single key's in-flight call, handling panics and
+// runtime.Goexit, and delivering the result to every DoChan subscriber.
+// Re-panicking and re-invoking runtime.Goexit for Do callers happens in
+// result, once they observe c.err.
+func (g *SFGroup[K, V]) doCall(c *call[V], key K, fn func() (V, error)) {
+	normalReturn := false
+	recovered := false
+
+	defer func() {
+		if !normalReturn && !recovered {
+			c.err = errGoexit
+		}
+
+		c.wg.Done()
+		g.mu.Lock()
+		if !c.forgotten {
+			delete(g.m, key)
+		}
+		g.mu.Unlock()
+
+		for _, ch := range c.chans {
+			ch <- Result[V]{Val: c.val, Err: c.err, Shared: c.dups > 0}
+		}
+	}()
+
+	func() {
+		defer func() {
+			if !normalReturn {
+				if r := recover(); r != nil {
+					c.err = &PanicError{
+						Value:      r,
+						StackTrace: trimRuntimeFrame(debug.Stack()),
+					}
+				}
+			}
+		}()
+
+		c.val, c.err = fn()
+		normalReturn = true
+	}()
+
+	if !normalReturn {
+		recovered = true
+	}
+}
+
+// Forget tells the SFGroup to forget about a key. Future calls to Do for
+// this key will call fn rather than waiting for an earlier call to
+// complete.
+func (g *SFGroup[K, V]) Forget(key K) {
+	g.mu.Lock()
+	if c, ok := g.m[key]; ok {
+		c.forgotten = true
+	}
+	delete(g.m, key)
+	g.mu.Unlock()
+}
+
+// trimRuntimeFrame trims the leading "goroutine N [status]:" line from a
+// debug.Stack() dump, since the originating goroutine may no longer exist
+// by the time other waiters observe the stack trace.
+func trimRuntimeFrame(stack []byte) string {
+	if bytes.HasPrefix(stack, goroutinePrefix) {
+		if i := bytes.IndexByte(stack, '\n'); i >= 0 {
+			stack = stack[i+1:]
+		}
+	}
+	return string(stack)
+}